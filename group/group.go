@@ -0,0 +1,75 @@
+/*
+Package group wraps [errgroup.Group] with the bits of bookkeeping that most callers end up
+re-implementing by hand: members are registered with a name so that it's possible to tell which
+ones are still running (useful when reporting a shutdown that's taking too long), and the Context
+returned alongside the Group is guaranteed to be done once the Context it was derived from is
+cancelled, whatever the reason - a member returning an error, a member panicking, or something
+entirely external to the group cancelling the parent.
+
+[errgroup.Group]: https://pkg.go.dev/golang.org/x/sync/errgroup#Group
+*/
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrMemberPanicked is wrapped into the error a panicking member (see [Group.Go]) is reported
+// with, both by [Group.Wait] and in the cancellation cause of the Context returned by
+// [WithContext].
+var ErrMemberPanicked = errors.New("group member panicked")
+
+/*
+Group is a thin wrapper around [errgroup.Group] that additionally tracks (via an embedded
+[Tracker]) the name each member was registered with (see [Group.Go]) for as long as it's running.
+
+The zero value isn't usable, construct a Group with [WithContext].
+*/
+type Group struct {
+	eg *errgroup.Group
+	t  Tracker
+}
+
+/*
+WithContext mirrors [errgroup.WithContext]: it returns a new Group and an associated Context
+derived from ctx. The derived Context is cancelled the first time a member's func returns a
+non-nil error, a member panics, or ctx itself is done - whichever happens first.
+*/
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	eg, gctx := errgroup.WithContext(ctx)
+	return &Group{eg: eg}, gctx
+}
+
+/*
+Go runs fn in a new goroutine, same as [errgroup.Group.Go], registering "name" with the Group for
+the duration fn runs - see [Group.Running]. Should fn panic the panic is recovered and reported as
+fn's error (wrapping [ErrMemberPanicked]) instead of taking down the whole process, so the other
+members still get a chance to observe the cancelled Context and shut down cleanly.
+*/
+func (g *Group) Go(name string, fn func() error) {
+	g.eg.Go(func() (err error) {
+		g.t.start(name)
+		defer g.t.stop(name)
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%s: %w: %v", name, ErrMemberPanicked, r)
+			}
+		}()
+		return fn()
+	})
+}
+
+// Running returns the names of the members currently running, in no particular order.
+func (g *Group) Running() []string {
+	return g.t.Running()
+}
+
+// Wait blocks until all members launched via [Group.Go] have returned, then returns the first
+// non-nil error (if any), same as [errgroup.Group.Wait].
+func (g *Group) Wait() error {
+	return g.eg.Wait()
+}
@@ -0,0 +1,96 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_Group_parentCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g, gctx := WithContext(ctx)
+
+	done := make(chan error, 1)
+	g.Go("watcher", func() error {
+		<-gctx.Done()
+		return gctx.Err()
+	})
+
+	cancel()
+
+	select {
+	case done <- g.Wait():
+	case <-time.After(time.Second):
+		t.Fatal("Wait didn't return within timeout")
+	}
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %q, got %q", context.Canceled, err)
+	}
+}
+
+func Test_Group_memberError(t *testing.T) {
+	t.Parallel()
+
+	expErr := errors.New("boom")
+	g, gctx := WithContext(context.Background())
+
+	g.Go("failing", func() error { return expErr })
+	g.Go("watcher", func() error {
+		<-gctx.Done()
+		return gctx.Err()
+	})
+
+	if err := g.Wait(); !errors.Is(err, expErr) {
+		t.Errorf("expected %q, got %q", expErr, err)
+	}
+}
+
+func Test_Group_memberPanic(t *testing.T) {
+	t.Parallel()
+
+	g, gctx := WithContext(context.Background())
+	g.Go("panicking", func() error { panic("boom") })
+
+	select {
+	case <-gctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("group context wasn't cancelled within timeout")
+	}
+
+	if err := g.Wait(); !errors.Is(err, ErrMemberPanicked) {
+		t.Errorf("expected error to wrap %q, got %q", ErrMemberPanicked, err)
+	}
+}
+
+func Test_Group_Running(t *testing.T) {
+	t.Parallel()
+
+	g, _ := WithContext(context.Background())
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	g.Go("slow", func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+
+	names := g.Running()
+	if len(names) != 1 || names[0] != "slow" {
+		t.Errorf("expected [slow], got %v", names)
+	}
+
+	close(release)
+	if err := g.Wait(); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+
+	if names := g.Running(); len(names) != 0 {
+		t.Errorf("expected no members running after Wait, got %v", names)
+	}
+}
@@ -0,0 +1,58 @@
+package group
+
+import "sync"
+
+/*
+Tracker records which named tasks are currently running. Its zero value is ready to use - it backs
+[Group]'s own name-tracking, and is also usable standalone via [NamedGo] for callers that don't go
+through [Group] (eg a plain [errgroup.Group]).
+
+[errgroup.Group]: https://pkg.go.dev/golang.org/x/sync/errgroup#Group
+*/
+type Tracker struct {
+	mu      sync.Mutex
+	running map[string]struct{}
+}
+
+func (t *Tracker) start(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.running == nil {
+		t.running = map[string]struct{}{}
+	}
+	t.running[name] = struct{}{}
+}
+
+func (t *Tracker) stop(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.running, name)
+}
+
+// Running returns the names currently registered as running (in no particular order).
+func (t *Tracker) Running() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	names := make([]string, 0, len(t.running))
+	for name := range t.running {
+		names = append(names, name)
+	}
+	return names
+}
+
+/*
+NamedGo registers "fn" with "g" (typically an [errgroup.Group], anything with a matching Go method
+will do) the same way a plain g.Go(fn) call would, but records "name" with "t" for as long as "fn"
+is running - letting callers report which task is still running when a deadline is reached.
+
+	var running group.Tracker
+	group.NamedGo(&running, g, "http-server", func() error { return httpsrv.Run(ctx, srv) })
+*/
+func NamedGo(t *Tracker, g interface{ Go(func() error) }, name string, fn func() error) {
+	g.Go(func() error {
+		t.start(name)
+		defer t.stop(name)
+		return fn()
+	})
+}
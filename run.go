@@ -0,0 +1,82 @@
+package wake
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/ainvaltin/wake/group"
+)
+
+/*
+Task is a named unit of work run by [Run] - "Name" is used purely for diagnostics (see
+Config.OnTimeout), "Func" is the work itself and is called with a Context that is done once Run
+starts shutting down (ie after the quit signal was received).
+*/
+type Task struct {
+	Name string
+	Func func(ctx context.Context) error
+}
+
+// Named is a convenience constructor for a [Task].
+func Named(name string, fn func(ctx context.Context) error) Task {
+	return Task{Name: name, Func: fn}
+}
+
+/*
+Config configures [Run].
+*/
+type Config struct {
+	// Signals overrides the default quit signals listened for, see [ListenForQuitSignal]. Zero
+	// value means the ListenForQuitSignal default ([os.Interrupt] and [syscall.SIGTERM]) is used.
+	Signals []os.Signal
+	// ShutdownTimeout bounds how long Run waits for the tasks to return after the quit signal
+	// arrives before giving up and returning [ErrWaitDeadlineExceeded]. Zero (the default) means
+	// wait for as long as it takes.
+	ShutdownTimeout time.Duration
+	// OnTimeout, when not nil, is called with the names of the tasks still running when
+	// ShutdownTimeout is reached.
+	OnTimeout func([]string)
+}
+
+/*
+Run is the single call that ties [ListenForQuitSignal], [group.Group] and [WaitWithDeadline]
+together into the lifecycle every service built on wake ends up needing: it starts every task in
+its own named goroutine alongside a member listening for the quit signal, and once that signal (or
+ctx) arrives waits for the tasks to return, enforcing cfg.ShutdownTimeout.
+
+	func main() {
+		srv := &service{}
+		err := wake.Run(context.Background(), wake.Config{ShutdownTimeout: time.Second},
+			wake.Named("http-server", func(ctx context.Context) error {
+				return httpsrv.Run(ctx, srv.endpoints())
+			}),
+		)
+		if err != nil && !errors.Is(err, wake.ErrReceivedQuitSignal) {
+			log.Fatal(err)
+		}
+	}
+
+This replaces the boilerplate shown in the [WaitWithTimeout] doc example for the common case where
+the group, the signal handling and the shutdown deadline don't need to be wired up by hand.
+*/
+func Run(ctx context.Context, cfg Config, tasks ...Task) error {
+	g, gctx := group.WithContext(ctx)
+
+	g.Go("wake.ListenForQuitSignal", func() error { return ListenForQuitSignal(gctx, cfg.Signals...) })
+	for _, task := range tasks {
+		task := task
+		g.Go(task.Name, func() error { return task.Func(gctx) })
+	}
+
+	if cfg.ShutdownTimeout <= 0 {
+		return g.Wait()
+	}
+
+	err := WaitWithTimeout(gctx, cfg.ShutdownTimeout, g.Wait)
+	if errors.Is(err, ErrWaitDeadlineExceeded) && cfg.OnTimeout != nil {
+		cfg.OnTimeout(g.Running())
+	}
+	return err
+}
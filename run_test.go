@@ -0,0 +1,102 @@
+package wake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parent context cancelled, tasks return in time", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- Run(ctx, Config{ShutdownTimeout: time.Second},
+				Named("quick-task", func(ctx context.Context) error {
+					<-ctx.Done()
+					return nil
+				}),
+			)
+		}()
+		cancel()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected error %q, got %q", context.Canceled, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Run didn't return within timeout")
+		}
+	})
+
+	t.Run("task still running at shutdown deadline is reported", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		reported := make(chan []string, 1)
+		done := make(chan error, 1)
+		go func() {
+			done <- Run(ctx, Config{
+				ShutdownTimeout: 100 * time.Millisecond,
+				OnTimeout:       func(names []string) { reported <- names },
+			},
+				Named("stuck-task", func(ctx context.Context) error {
+					<-ctx.Done()
+					time.Sleep(time.Second)
+					return nil
+				}),
+			)
+		}()
+		cancel()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, ErrWaitDeadlineExceeded) {
+				t.Errorf("expected error %q, got %q", ErrWaitDeadlineExceeded, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Run didn't return within timeout")
+		}
+
+		select {
+		case names := <-reported:
+			if len(names) != 1 || names[0] != "stuck-task" {
+				t.Errorf("expected [stuck-task], got %v", names)
+			}
+		case <-time.After(time.Second):
+			t.Error("OnTimeout wasn't called")
+		}
+	})
+
+	t.Run("shutdown deadline starts at shutdown, not at Run() call time", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		started := time.Now()
+		done := make(chan error, 1)
+		go func() {
+			done <- Run(ctx, Config{ShutdownTimeout: 2 * time.Second},
+				Named("slow-to-stop", func(ctx context.Context) error {
+					<-ctx.Done()
+					time.Sleep(1500 * time.Millisecond)
+					return nil
+				}),
+			)
+		}()
+
+		time.Sleep(time.Second) // Run is already running for a second before shutdown begins
+		cancel()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected error %q, got %q", context.Canceled, err)
+			}
+			if elapsed := time.Since(started); elapsed < 2*time.Second {
+				t.Errorf("task didn't get its full ShutdownTimeout to clean up, returned after %s", elapsed)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatal("Run didn't return within timeout")
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package wake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+)
+
+/*
+ErrReceivedQuitSignal is returned by [ListenForQuitSignal] when it receives one of the
+quit signals it listened for.
+*/
+var ErrReceivedQuitSignal = errors.New("received quit signal")
+
+/*
+ListenForQuitSignal is meant to be used with [errgroup] - as one group member this func causes the
+group context to be cancelled when quit signal is sent.
+Benefit using it over [signal.NotifyContext] is that signal.NotifyContext returns [context.Cancelled]
+no matter whether the signal was sent or parent ctx was cancelled, ListenForQuitSignal returns
+[ErrReceivedQuitSignal] for the former case (use [errors.Is] to check for it as it might be wrapped
+inside another error describing the signal).
+
+	g.Go(func() error { return wake.ListenForQuitSignal(ctx) })
+
+When no signals (the sig parameter) is provided (as in above example) it listens for
+[DefaultQuitSignals].
+
+If differentiation between cancellation cases is not a concern then following func is equivalent to
+the previous example:
+
+	g.Go(func() error {
+		ctx, stop := signal.NotifyContext(ctx, wake.DefaultQuitSignals()...)
+		defer stop()
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+[errgroup]: https://pkg.go.dev/golang.org/x/sync/errgroup
+*/
+func ListenForQuitSignal(ctx context.Context, sig ...os.Signal) error {
+	if len(sig) == 0 {
+		sig = DefaultQuitSignals()
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sig...)
+	defer signal.Stop(c)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s := <-c:
+		return fmt.Errorf("%s: %w", s, ErrReceivedQuitSignal)
+	}
+}
+
+/*
+ErrForceQuit is returned (or passed to the "onForceQuit" callback, wrapped) by
+[ListenForQuitSignalStaged] when a second quit signal arrives while the shutdown triggered by the
+first one is still in progress.
+*/
+var ErrForceQuit = errors.New("received second quit signal, forcing shutdown")
+
+/*
+ListenForQuitSignalStaged implements the "press Ctrl-C twice" shutdown pattern familiar from tools
+like mage: the first quit signal behaves exactly like [ListenForQuitSignal] (ie causes the group
+context to be cancelled by returning an error wrapping [ErrReceivedQuitSignal]), but the func keeps
+listening for a second signal arriving while graceful shutdown is still under way. When that second
+signal is received "onForceQuit" is called with the signal that triggered it - use it to os.Exit
+immediately, cancel some other context shared by the group, or whatever "stop now" means for the
+caller. When "onForceQuit" is nil the second signal is logged to stderr and the process is terminated
+with [os.Exit](1).
+
+	g.Go(func() error {
+		return wake.ListenForQuitSignalStaged(ctx, func(s os.Signal) error {
+			os.Exit(1)
+			return nil // unreachable
+		})
+	})
+
+As with ListenForQuitSignal, when no signals (the sig parameter) are provided it listens for
+[DefaultQuitSignals].
+*/
+func ListenForQuitSignalStaged(ctx context.Context, onForceQuit func(os.Signal) error, sig ...os.Signal) error {
+	if len(sig) == 0 {
+		sig = DefaultQuitSignals()
+	}
+
+	c := make(chan os.Signal, 2)
+	signal.Notify(c, sig...)
+
+	select {
+	case <-ctx.Done():
+		signal.Stop(c)
+		return ctx.Err()
+	case s := <-c:
+		// the registration (signal.Notify) must outlive this call - it's stopped once the second
+		// signal has been handled, by forceQuitOnSecondSignal itself
+		go forceQuitOnSecondSignal(c, onForceQuit)
+		return fmt.Errorf("%s: %w", s, ErrReceivedQuitSignal)
+	}
+}
+
+func forceQuitOnSecondSignal(c chan os.Signal, onForceQuit func(os.Signal) error) {
+	defer signal.Stop(c)
+
+	s := <-c
+
+	var err error
+	if onForceQuit != nil {
+		err = onForceQuit(s)
+	} else {
+		err = fmt.Errorf("%s: %w", s, ErrForceQuit)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
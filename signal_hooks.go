@@ -0,0 +1,63 @@
+package wake
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+)
+
+/*
+OnSignal runs until ctx is done, dispatching every signal it receives to the handler registered
+for it in "handlers" - the canonical use case being SIGUSR1/SIGUSR2 starting/stopping a
+[runtime/trace] trace or dumping goroutine stacks on demand:
+
+	g.Go(func() error {
+		return wake.OnSignal(ctx, map[os.Signal]func(os.Signal) error{
+			syscall.SIGUSR1: func(os.Signal) error { return startTrace() },
+			syscall.SIGUSR2: func(os.Signal) error { return stopTrace() },
+		})
+	})
+
+Unlike [ListenForQuitSignal], a handler isn't one-shot - every delivery re-arms it for the next
+one. A handler that panics doesn't take OnSignal down: the panic is recovered and reported to
+stderr, and OnSignal keeps dispatching subsequent signals. A handler that returns a non-nil error
+does stop OnSignal, which then returns that error.
+
+OnSignal is meant to be run as its own group member alongside ListenForQuitSignal, listening for a
+disjoint set of signals (eg SIGUSR1/SIGUSR2 here, [os.Interrupt]/SIGTERM there). [signal.Notify]
+delivers a signal to every channel registered for it, so the two functions - each registering and
+owning its own channel - don't race over a shared one.
+
+[runtime/trace]: https://pkg.go.dev/runtime/trace
+*/
+func OnSignal(ctx context.Context, handlers map[os.Signal]func(os.Signal) error) error {
+	sig := make([]os.Signal, 0, len(handlers))
+	for s := range handlers {
+		sig = append(sig, s)
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sig...)
+	defer signal.Stop(c)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case s := <-c:
+			if err := callSignalHandler(handlers[s], s); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func callSignalHandler(h func(os.Signal) error, s os.Signal) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "wake.OnSignal: handler for %s panicked: %v\n", s, r)
+		}
+	}()
+	return h(s)
+}
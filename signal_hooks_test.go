@@ -0,0 +1,178 @@
+//go:build unix
+
+package wake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func Test_OnSignal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parent context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- OnSignal(ctx, map[os.Signal]func(os.Signal) error{
+				syscall.SIGUSR1: func(os.Signal) error { return nil },
+			})
+		}()
+		cancel()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected error %q, got %q", context.Canceled, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("OnSignal didn't return within timeout")
+		}
+	})
+
+	t.Run("handler is re-armed after each delivery", func(t *testing.T) {
+		s, err := runTestCommand("TestOnSignalReArmed")
+		if err != nil {
+			t.Fatalf("failed to run test: %v", err)
+		}
+		if s != `1,2` {
+			t.Errorf("unexpected return value:\n%s\n", s)
+		}
+	})
+
+	t.Run("panicking handler doesn't stop OnSignal", func(t *testing.T) {
+		s, err := runTestCommand("TestOnSignalPanicRecovered")
+		if err != nil {
+			t.Fatalf("failed to run test: %v", err)
+		}
+		if s != `recovered` {
+			t.Errorf("unexpected return value:\n%s\n", s)
+		}
+	})
+
+	t.Run("handler error stops OnSignal", func(t *testing.T) {
+		s, err := runTestCommand("TestOnSignalHandlerError")
+		if err != nil {
+			t.Fatalf("failed to run test: %v", err)
+		}
+		if s != `handler error` {
+			t.Errorf("unexpected return value:\n%s\n", s)
+		}
+	})
+}
+
+func TestOnSignalReArmed(t *testing.T) {
+	if os.Getenv("GO_TEST_PROCESS") != "1" {
+		return
+	}
+
+	calls := make(chan int, 2)
+	n := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- OnSignal(context.Background(), map[os.Signal]func(os.Signal) error{
+			syscall.SIGUSR1: func(os.Signal) error {
+				n++
+				calls <- n
+				return nil
+			},
+		})
+	}()
+	time.Sleep(500 * time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		if err := sendSignalToItself(syscall.SIGUSR1); err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+		select {
+		case v := <-calls:
+			if i > 0 {
+				fmt.Print(",")
+			}
+			fmt.Printf("%d", v)
+		case <-time.After(2 * time.Second):
+			fmt.Print("handler wasn't invoked within timeout")
+			os.Exit(0)
+		}
+	}
+	os.Exit(0)
+}
+
+func TestOnSignalPanicRecovered(t *testing.T) {
+	if os.Getenv("GO_TEST_PROCESS") != "1" {
+		return
+	}
+
+	calls := make(chan struct{}, 1)
+	first := true
+	done := make(chan error, 1)
+	go func() {
+		done <- OnSignal(context.Background(), map[os.Signal]func(os.Signal) error{
+			syscall.SIGUSR1: func(os.Signal) error {
+				if first {
+					first = false
+					panic("boom")
+				}
+				calls <- struct{}{}
+				return nil
+			},
+		})
+	}()
+	time.Sleep(500 * time.Millisecond)
+
+	if err := sendSignalToItself(syscall.SIGUSR1); err != nil {
+		fmt.Print(err.Error())
+		os.Exit(1)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if err := sendSignalToItself(syscall.SIGUSR1); err != nil {
+		fmt.Print(err.Error())
+		os.Exit(1)
+	}
+
+	select {
+	case <-calls:
+		fmt.Print("recovered")
+	case <-time.After(2 * time.Second):
+		fmt.Print("handler wasn't invoked after the panicking delivery")
+	}
+	os.Exit(0)
+}
+
+func TestOnSignalHandlerError(t *testing.T) {
+	if os.Getenv("GO_TEST_PROCESS") != "1" {
+		return
+	}
+
+	expErr := errors.New("handler error")
+	done := make(chan error, 1)
+	go func() {
+		done <- OnSignal(context.Background(), map[os.Signal]func(os.Signal) error{
+			syscall.SIGUSR1: func(os.Signal) error { return expErr },
+		})
+	}()
+	time.Sleep(500 * time.Millisecond)
+
+	if err := sendSignalToItself(syscall.SIGUSR1); err != nil {
+		fmt.Print(err.Error())
+		os.Exit(1)
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, expErr) {
+			fmt.Printf("unexpected error: %v", err)
+		} else {
+			fmt.Print(err.Error())
+		}
+	case <-time.After(2 * time.Second):
+		fmt.Print("OnSignal didn't return within timeout")
+	}
+	os.Exit(0)
+}
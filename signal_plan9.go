@@ -0,0 +1,17 @@
+//go:build plan9
+
+package wake
+
+import "os"
+
+/*
+DefaultQuitSignals returns the signals [ListenForQuitSignal] and [ListenForQuitSignalStaged]
+listen for when the caller doesn't supply any.
+
+Plan 9 identifies signals by note string rather than a [syscall.Signal] value and has no SIGTERM
+equivalent worth listening for by default, so only [os.Interrupt] (delivered on a "interrupt" note)
+is included.
+*/
+func DefaultQuitSignals() []os.Signal {
+	return []os.Signal{os.Interrupt}
+}
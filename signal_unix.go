@@ -0,0 +1,16 @@
+//go:build unix
+
+package wake
+
+import (
+	"os"
+	"syscall"
+)
+
+/*
+DefaultQuitSignals returns the signals [ListenForQuitSignal] and [ListenForQuitSignalStaged]
+listen for when the caller doesn't supply any: [os.Interrupt] and [syscall.SIGTERM].
+*/
+func DefaultQuitSignals() []os.Signal {
+	return []os.Signal{os.Interrupt, syscall.SIGTERM}
+}
@@ -1,3 +1,5 @@
+//go:build unix
+
 package wake
 
 import (
@@ -12,76 +14,6 @@ import (
 	"time"
 )
 
-func Test_WaitWithTimeout(t *testing.T) {
-	t.Parallel()
-
-	t.Run("wait returns nil before timeout", func(t *testing.T) {
-		ctx, cancel := context.WithCancel(context.Background())
-		res := make(chan error)
-		go func() {
-			res <- WaitWithTimeout(ctx, time.Second, func() error { return nil })
-		}()
-		// cancel the "group ctx", this triggers the wait call with timeout
-		cancel()
-		select {
-		case err := <-res:
-			if err != nil {
-				t.Errorf("expected no error, got: %v", err)
-			}
-		case <-time.After(500 * time.Millisecond):
-			t.Error("WaitWithTimeout didn't return within timeout")
-		}
-	})
-
-	expectError := func(t *testing.T, err, expErr error) {
-		t.Helper()
-		if err != nil {
-			if !errors.Is(err, expErr) {
-				t.Errorf("expected error\n%v\nbut got\n%v", expErr, err)
-			}
-		} else {
-			t.Errorf("got nil error while expected to get error: %v", expErr)
-		}
-	}
-
-	t.Run("wait returns non-nil error before timeout", func(t *testing.T) {
-		ctx, cancel := context.WithCancel(context.Background())
-		expErr := fmt.Errorf("error from wait")
-		res := make(chan error)
-		go func() {
-			res <- WaitWithTimeout(ctx, time.Second, func() error { return expErr })
-		}()
-		// cancel the "group ctx", this triggers the wait call with timeout
-		cancel()
-		select {
-		case err := <-res:
-			expectError(t, err, expErr)
-		case <-time.After(500 * time.Millisecond):
-			t.Error("WaitWithTimeout didn't return within timeout")
-		}
-	})
-
-	t.Run("wait blocks longer than timeout", func(t *testing.T) {
-		ctx, cancel := context.WithCancel(context.Background())
-		res := make(chan error)
-		go func() {
-			res <- WaitWithTimeout(ctx, time.Second,
-				func() error {
-					time.Sleep(1500 * time.Millisecond)
-					return nil
-				})
-		}()
-		// cancel the "group ctx", this triggers the wait call with timeout
-		cancel()
-		select {
-		case err := <-res:
-			expectError(t, err, ErrWaitDeadlineExceeded)
-		case <-time.After(1100 * time.Millisecond):
-			t.Error("WaitWithTimeout didn't return within timeout")
-		}
-	})
-}
-
 func Test_ListenForQuitSignal(t *testing.T) {
 	t.Parallel()
 
@@ -163,6 +95,42 @@ func Test_ListenForQuitSignal(t *testing.T) {
 	})
 }
 
+func Test_ListenForQuitSignalStaged(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parent context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(700 * time.Millisecond)
+			cancel()
+		}()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- ListenForQuitSignalStaged(ctx, nil)
+		}()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected error %q, got %q", context.Canceled, err.Error())
+			}
+		case <-time.After(time.Second):
+			t.Fatal("test didn't complete within timeout")
+		}
+	})
+
+	t.Run("second signal triggers onForceQuit", func(t *testing.T) {
+		s, err := runTestCommand("TestSignalStagedForceQuit")
+		if err != nil {
+			t.Fatalf("failed to run test: %v", err)
+		}
+		if s != `terminated: received quit signal|forced:terminated` {
+			t.Errorf("unexpected return value:\n%s\n", s)
+		}
+	})
+}
+
 func runTestCommand(testName string) (string, error) {
 	cmd := exec.Command(os.Args[0], "-test.run=^"+testName+"$")
 	cmd.Env = []string{"GO_TEST_PROCESS=1"}
@@ -256,3 +224,50 @@ func TestSignalSIGKILL(t *testing.T) {
 	// by default we do not listen for SIGKILL, ask for it
 	testListenForQuitSignal(syscall.SIGKILL, syscall.SIGKILL)
 }
+
+func TestSignalStagedForceQuit(t *testing.T) {
+	if os.Getenv("GO_TEST_PROCESS") != "1" {
+		return
+	}
+
+	forced := make(chan os.Signal, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- ListenForQuitSignalStaged(context.Background(), func(s os.Signal) error {
+			forced <- s
+			return nil
+		}, syscall.SIGTERM)
+	}()
+	// delay to allow the goroutine to register the signal handler
+	time.Sleep(500 * time.Millisecond)
+
+	if err := sendSignalToItself(syscall.SIGTERM); err != nil {
+		fmt.Fprint(os.Stdout, err.Error())
+		os.Exit(1)
+	}
+
+	select {
+	case err := <-done:
+		if err == nil || !errors.Is(err, ErrReceivedQuitSignal) {
+			fmt.Printf("unexpected result of the first signal: %v", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(os.Stdout, err.Error())
+	case <-time.After(2 * time.Second):
+		fmt.Print("didn't receive result of the first signal within timeout")
+		os.Exit(0)
+	}
+
+	if err := sendSignalToItself(syscall.SIGTERM); err != nil {
+		fmt.Fprint(os.Stdout, err.Error())
+		os.Exit(1)
+	}
+
+	select {
+	case s := <-forced:
+		fmt.Fprintf(os.Stdout, "|forced:%s", s)
+	case <-time.After(2 * time.Second):
+		fmt.Print("onForceQuit wasn't called within timeout")
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package wake
+
+import (
+	"os"
+	"syscall"
+)
+
+/*
+DefaultQuitSignals returns the signals [ListenForQuitSignal] and [ListenForQuitSignalStaged]
+listen for when the caller doesn't supply any.
+
+Windows doesn't have real signal delivery - the os/signal package synthesizes [os.Interrupt] for
+both a CTRL_C_EVENT and a CTRL_BREAK_EVENT (see the os/signal package docs), so that's the only
+signal worth listening for by default. [syscall.SIGTERM] is synthesized from CTRL_CLOSE_EVENT,
+CTRL_LOGOFF_EVENT and CTRL_SHUTDOWN_EVENT, which a console process can still receive, so it's
+included too; SIGQUIT/SIGHUP and friends have no Windows equivalent.
+*/
+func DefaultQuitSignals() []os.Signal {
+	return []os.Signal{os.Interrupt, syscall.SIGTERM}
+}
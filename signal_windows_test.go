@@ -0,0 +1,88 @@
+//go:build windows
+
+package wake
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var (
+	kernel32                     = syscall.NewLazyDLL("kernel32.dll")
+	procGenerateConsoleCtrlEvent = kernel32.NewProc("GenerateConsoleCtrlEvent")
+)
+
+const ctrlBreakEvent = 1
+
+func Test_ListenForQuitSignal_CTRL_BREAK(t *testing.T) {
+	t.Parallel()
+
+	// a CTRL_BREAK_EVENT is synthesized by the runtime as os.Interrupt, not syscall.SIGTERM -
+	// there's no way to trigger the real SIGTERM path (CTRL_CLOSE/LOGOFF/SHUTDOWN_EVENT) via
+	// GenerateConsoleCtrlEvent, so that path is untested here.
+	s, err := runWindowsTestCommand("TestSignalCTRLBREAK")
+	if err != nil {
+		t.Fatalf("failed to run test: %v", err)
+	}
+	if s != `interrupt: received quit signal` {
+		t.Errorf("unexpected return value:\n%s\n", s)
+	}
+}
+
+/*
+runWindowsTestCommand spawns the current test binary into its own console process group (so that
+the CTRL_BREAK_EVENT sent to it doesn't also reach this, the parent, process) and runs just the
+named test in it - analogous to how the os/signal package's own Windows tests exercise
+GenerateConsoleCtrlEvent.
+*/
+func runWindowsTestCommand(testName string) (string, error) {
+	cmd := exec.Command(os.Args[0], "-test.run=^"+testName+"$")
+	cmd.Env = []string{"GO_TEST_PROCESS=1"}
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("failed to start the command: %w", err)
+	}
+
+	// delay to allow the child to register the signal handler
+	time.Sleep(500 * time.Millisecond)
+
+	if r, _, err := procGenerateConsoleCtrlEvent.Call(ctrlBreakEvent, uintptr(cmd.Process.Pid)); r == 0 {
+		return "", fmt.Errorf("GenerateConsoleCtrlEvent failed: %w", err)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return "", fmt.Errorf("failed to run the command: %w", err)
+	}
+
+	return out.String(), nil
+}
+
+func TestSignalCTRLBREAK(t *testing.T) {
+	if os.Getenv("GO_TEST_PROCESS") != "1" {
+		return
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- ListenForQuitSignal(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			fmt.Print("unexpectedly got nil error")
+		} else {
+			fmt.Fprint(os.Stdout, err.Error())
+		}
+	case <-time.After(2 * time.Second):
+		fmt.Print("test didn't complete within timeout")
+	}
+	os.Exit(0)
+}
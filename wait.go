@@ -0,0 +1,169 @@
+package wake
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ainvaltin/wake/group"
+)
+
+/*
+ErrWaitDeadlineExceeded is returned by [WaitWithTimeout], [WaitWithDeadline] and
+[WaitWithTimeoutFunc] when the function they wait for to complete doesn't finish before the
+deadline (timeout or shutdownCtx cancellation) is reached.
+*/
+var ErrWaitDeadlineExceeded = errors.New("wait func didn't complete within timeout")
+
+/*
+WaitWithTimeout is a helper function to support wait with timeout when using "errgroup pattern".
+
+  - "ctx" is context of the waitgroup, it's being cancelled signals to start wait with timeout;
+  - "timeout" is the duration for how long to wait for the "wait" func to return before returning [ErrWaitDeadlineExceeded] error;
+  - "wait" is the Wait function of the group.
+
+The "wait" function will be called once the "ctx" is cancelled (it's Done chan is closed).
+When the "wait" function returns before timeout is reached error returned by it is returned by WaitWithTimeout.
+When timeout is reached before the "wait" function finishes WaitWithTimeout returns [ErrWaitDeadlineExceeded].
+
+WaitWithTimeout mustn't be a member of the group, it would be used instead of "plain group.Wait()" call.
+Example of using WaitWithTimeout to return from the "main run function" (which presumably will stop the service) even when
+all subprocesses haven't gracefully shut down after one second has elapsed since receiving the quit signal:
+
+	func run(ctx context.Context, cfg Configuration) error {
+		g, ctx := errgroup.WithContext(ctx)
+
+		g.Go(func() error { return wake.ListenForQuitSignal(ctx) })
+
+		g.Go(func() error {
+			s := &service{cfg: cfg}
+			return httpsrv.Run(ctx, cfg.HttpServer(s.endpoints()))
+		})
+
+		return wake.WaitWithTimeout(ctx, time.Second, g.Wait)
+	}
+
+Keep in mind that when timeout is reached the group members that haven't stopped will keep doing whatever they do,
+it is just that we do not wait for them to finish anymore!
+
+To use this function with [sync.WaitGroup] just wrap the g.Wait() call, ie
+
+	wake.WaitWithTimeout(ctx, time.Second, func() error { g.Wait(); return nil })
+
+WaitWithTimeout is implemented on top of [WaitWithDeadline], the timeout clock starts ticking only
+once "ctx" is done - use WaitWithDeadline directly when the deadline itself needs to be cancellable
+(eg by a second quit signal).
+*/
+func WaitWithTimeout(ctx context.Context, timeout time.Duration, wait func() error) error {
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return WaitWithDeadline(ctx, shutdownCtx, wait)
+}
+
+/*
+WaitWithDeadline is like [WaitWithTimeout] but instead of a plain duration the deadline is
+expressed as "shutdownCtx" - a context whose cancellation (for whatever reason: timeout, a second
+quit signal, an orchestrator giving up) ends the wait. "ctx" is, same as in WaitWithTimeout, the
+context of the group - "wait" is called once it is done and "shutdownCtx" starts being observed
+only from that point on.
+
+	shutdownCtx, cancel := context.WithCancel(context.Background())
+	g.Go(func() error {
+		return wake.ListenForQuitSignalStaged(ctx, func(os.Signal) error { cancel(); return nil })
+	})
+	return wake.WaitWithDeadline(ctx, shutdownCtx, g.Wait)
+
+Here a second quit signal cancels "shutdownCtx" directly, causing WaitWithDeadline to stop waiting
+for the group immediately instead of waiting out some fixed timeout.
+*/
+func WaitWithDeadline(ctx context.Context, shutdownCtx context.Context, wait func() error) error {
+	<-ctx.Done()
+
+	rec := make(chan error, 1)
+	go func() { rec <- wait() }()
+
+	select {
+	case err := <-rec:
+		return err
+	case <-shutdownCtx.Done():
+		return ErrWaitDeadlineExceeded
+	}
+}
+
+/*
+WaitWithTimeoutFunc is a variant of [WaitWithTimeout] for the case where "wait" itself can observe
+the deadline - instead of a plain func() error, "wait" receives a context that is cancelled when
+the timeout fires, so group members that are still running can notice this and abort instead of
+being left running (leaked) forever:
+
+	return wake.WaitWithTimeoutFunc(ctx, time.Second, func(shutdownCtx context.Context) error {
+		done := make(chan error, 1)
+		go func() { done <- g.Wait() }()
+		select {
+		case err := <-done:
+			return err
+		case <-shutdownCtx.Done():
+			return shutdownCtx.Err()
+		}
+	})
+
+As with WaitWithTimeout, WaitWithTimeoutFunc returns [ErrWaitDeadlineExceeded] when "wait" hasn't
+returned before the timeout is reached, no matter whether "wait" itself reacted to the cancelled
+context or not.
+*/
+func WaitWithTimeoutFunc(ctx context.Context, timeout time.Duration, wait func(context.Context) error) error {
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	rec := make(chan error, 1)
+	go func() { rec <- wait(shutdownCtx) }()
+
+	select {
+	case err := <-rec:
+		return err
+	case <-shutdownCtx.Done():
+		return ErrWaitDeadlineExceeded
+	}
+}
+
+/*
+Tracker records which named tasks, registered via [NamedGo], are currently running. Its zero value
+is ready to use. It exists so that a deadline hook (see [Tracker.WaitWithTimeout]) can report which
+group members were still running when the deadline was reached, instead of just "something timed
+out". The tracking itself is done by the embedded [group.Tracker] - this type exists so the
+package-level [NamedGo] and [Tracker.WaitWithTimeout] helpers have somewhere to hang off.
+*/
+type Tracker struct {
+	group.Tracker
+}
+
+/*
+NamedGo registers "fn" with "g" (typically an [errgroup.Group], anything with a matching Go method
+will do) the same way a plain g.Go(fn) call would, but records "name" with "t" for as long as "fn"
+is running - letting a [Tracker.WaitWithTimeout] hook report the task if it is still running when
+the deadline is reached.
+
+	var running wake.Tracker
+	wake.NamedGo(&running, g, "http-server", func() error { return httpsrv.Run(ctx, srv) })
+*/
+func NamedGo(t *Tracker, g interface{ Go(func() error) }, name string, fn func() error) {
+	group.NamedGo(&t.Tracker, g, name, fn)
+}
+
+/*
+WaitWithTimeout is like the package-level [WaitWithTimeout], but on deadline it first calls
+"onTimeout" (when non-nil) with the names still registered as running on "t" (see [NamedGo]),
+before returning [ErrWaitDeadlineExceeded].
+*/
+func (t *Tracker) WaitWithTimeout(ctx context.Context, timeout time.Duration, onTimeout func([]string), wait func() error) error {
+	err := WaitWithTimeout(ctx, timeout, wait)
+	if errors.Is(err, ErrWaitDeadlineExceeded) && onTimeout != nil {
+		onTimeout(t.Running())
+	}
+	return err
+}
@@ -0,0 +1,196 @@
+package wake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func expectError(t *testing.T, err, expErr error) {
+	t.Helper()
+	if err != nil {
+		if !errors.Is(err, expErr) {
+			t.Errorf("expected error\n%v\nbut got\n%v", expErr, err)
+		}
+	} else {
+		t.Errorf("got nil error while expected to get error: %v", expErr)
+	}
+}
+
+func Test_WaitWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wait returns nil before timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		res := make(chan error)
+		go func() {
+			res <- WaitWithTimeout(ctx, time.Second, func() error { return nil })
+		}()
+		// cancel the "group ctx", this triggers the wait call with timeout
+		cancel()
+		select {
+		case err := <-res:
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		case <-time.After(500 * time.Millisecond):
+			t.Error("WaitWithTimeout didn't return within timeout")
+		}
+	})
+
+	t.Run("wait returns non-nil error before timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		expErr := fmt.Errorf("error from wait")
+		res := make(chan error)
+		go func() {
+			res <- WaitWithTimeout(ctx, time.Second, func() error { return expErr })
+		}()
+		// cancel the "group ctx", this triggers the wait call with timeout
+		cancel()
+		select {
+		case err := <-res:
+			expectError(t, err, expErr)
+		case <-time.After(500 * time.Millisecond):
+			t.Error("WaitWithTimeout didn't return within timeout")
+		}
+	})
+
+	t.Run("wait blocks longer than timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		res := make(chan error)
+		go func() {
+			res <- WaitWithTimeout(ctx, time.Second,
+				func() error {
+					time.Sleep(1500 * time.Millisecond)
+					return nil
+				})
+		}()
+		// cancel the "group ctx", this triggers the wait call with timeout
+		cancel()
+		select {
+		case err := <-res:
+			expectError(t, err, ErrWaitDeadlineExceeded)
+		case <-time.After(1100 * time.Millisecond):
+			t.Error("WaitWithTimeout didn't return within timeout")
+		}
+	})
+}
+
+func Test_WaitWithDeadline(t *testing.T) {
+	t.Parallel()
+
+	t.Run("shutdownCtx cancelled before wait returns", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		shutdownCtx, shutdownCancel := context.WithCancel(context.Background())
+		res := make(chan error)
+		go func() {
+			res <- WaitWithDeadline(ctx, shutdownCtx, func() error {
+				time.Sleep(time.Second)
+				return nil
+			})
+		}()
+		cancel()
+		// cancel the deadline early, eg as if a second quit signal arrived
+		time.Sleep(100 * time.Millisecond)
+		shutdownCancel()
+		select {
+		case err := <-res:
+			expectError(t, err, ErrWaitDeadlineExceeded)
+		case <-time.After(500 * time.Millisecond):
+			t.Error("WaitWithDeadline didn't return within timeout")
+		}
+	})
+
+	t.Run("wait returns before deadline", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second)
+		defer shutdownCancel()
+		res := make(chan error)
+		go func() {
+			res <- WaitWithDeadline(ctx, shutdownCtx, func() error { return nil })
+		}()
+		cancel()
+		select {
+		case err := <-res:
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		case <-time.After(500 * time.Millisecond):
+			t.Error("WaitWithDeadline didn't return within timeout")
+		}
+	})
+}
+
+func Test_WaitWithTimeoutFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wait observes the deadline", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		observedDone := make(chan bool, 1)
+		res := make(chan error)
+		go func() {
+			res <- WaitWithTimeoutFunc(ctx, 200*time.Millisecond, func(shutdownCtx context.Context) error {
+				<-shutdownCtx.Done()
+				observedDone <- true
+				return shutdownCtx.Err()
+			})
+		}()
+		cancel()
+		select {
+		case err := <-res:
+			expectError(t, err, ErrWaitDeadlineExceeded)
+		case <-time.After(time.Second):
+			t.Fatal("WaitWithTimeoutFunc didn't return within timeout")
+		}
+		select {
+		case <-observedDone:
+		case <-time.After(time.Second):
+			t.Error("wait func didn't observe the deadline being reached")
+		}
+	})
+}
+
+func Test_Tracker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports names still running at deadline", func(t *testing.T) {
+		var running Tracker
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		g := &goGroup{}
+		NamedGo(&running, g, "slow-task", func() error {
+			close(started)
+			<-release
+			return nil
+		})
+		<-started
+
+		reported := make(chan []string, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := running.WaitWithTimeout(ctx, 50*time.Millisecond, func(names []string) {
+			reported <- names
+		}, func() error { <-release; return nil })
+		expectError(t, err, ErrWaitDeadlineExceeded)
+		close(release)
+
+		select {
+		case names := <-reported:
+			if len(names) != 1 || names[0] != "slow-task" {
+				t.Errorf("expected [slow-task], got %v", names)
+			}
+		case <-time.After(time.Second):
+			t.Error("onTimeout wasn't called")
+		}
+	})
+}
+
+// goGroup is a minimal stand-in for *errgroup.Group, just enough to exercise NamedGo.
+type goGroup struct{}
+
+func (g *goGroup) Go(fn func() error) {
+	go fn() //nolint:errcheck
+}